@@ -0,0 +1,177 @@
+// Package gasprices implements the strategies the gas-oracle uses to
+// compute the L2 gas price that gets pushed on-chain.
+package gasprices
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/mantlenetworkio/mantle/gas-oracle/tokenprice"
+)
+
+// GetLatestBlockNumberFn returns the latest L2 block number.
+type GetLatestBlockNumberFn func() (uint64, error)
+
+// GetGasUsedByBlockFn returns the gas used by the given L2 block.
+type GetGasUsedByBlockFn func(number uint64) (uint64, error)
+
+// HeaderByNumberFn returns the L2 header at the given number, or the tip
+// when number is nil.
+type HeaderByNumberFn func(number *big.Int) (*types.Header, error)
+
+// TransactionsByBlockFn returns the transactions included in the L2 block
+// with the given hash.
+type TransactionsByBlockFn func(hash common.Hash) (types.Transactions, error)
+
+// UpdateL2GasPriceFn pushes a suggested gas price on-chain.
+type UpdateL2GasPriceFn func(gasPrice *big.Int) error
+
+// GasPriceUpdater is the interface implemented by the pricing strategies
+// the gas-oracle can be configured to run. Only one strategy is active at
+// a time; Config.gasPriceStrategy selects which.
+type GasPriceUpdater interface {
+	// UpdateGasPrice samples whatever on-chain signal the strategy needs
+	// and pushes an updated price when warranted.
+	UpdateGasPrice() error
+	// GetGasPrice returns the most recently suggested price.
+	GetGasPrice() *big.Int
+}
+
+// GasPricer tracks the target-throughput strategy's current price and
+// adjusts it towards a configured target gas-per-second rate.
+type GasPricer struct {
+	mu                       sync.RWMutex
+	gasPrice                 *big.Int
+	floorPrice               *big.Int
+	tokenPricer              *tokenprice.Client
+	targetGasPerSecondFn     func() float64
+	maxPercentChangePerEpoch float64
+}
+
+// NewGasPricer creates a GasPricer.
+func NewGasPricer(
+	currentPrice uint64,
+	floorPrice uint64,
+	tokenPricer *tokenprice.Client,
+	targetGasPerSecondFn func() float64,
+	maxPercentChangePerEpoch float64,
+) (*GasPricer, error) {
+	if maxPercentChangePerEpoch < 0 {
+		return nil, errors.New("maxPercentChangePerEpoch must be non-negative")
+	}
+	return &GasPricer{
+		gasPrice:                 new(big.Int).SetUint64(currentPrice),
+		floorPrice:               new(big.Int).SetUint64(floorPrice),
+		tokenPricer:              tokenPricer,
+		targetGasPerSecondFn:     targetGasPerSecondFn,
+		maxPercentChangePerEpoch: maxPercentChangePerEpoch,
+	}, nil
+}
+
+// UpdateGasPrice moves the tracked price towards the configured target
+// gas-per-second rate, clamped to at most maxPercentChangePerEpoch change
+// per call and never below floorPrice.
+func (g *GasPricer) UpdateGasPrice(gasPerSecond float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	target := g.targetGasPerSecondFn()
+	if target <= 0 {
+		return
+	}
+
+	ratio := gasPerSecond / target
+	if max := 1 + g.maxPercentChangePerEpoch; ratio > max {
+		ratio = max
+	}
+	if min := 1 - g.maxPercentChangePerEpoch; ratio < min {
+		ratio = min
+	}
+
+	next := new(big.Float).Mul(new(big.Float).SetInt(g.gasPrice), big.NewFloat(ratio))
+	nextInt, _ := next.Int(nil)
+	if nextInt.Cmp(g.floorPrice) < 0 {
+		nextInt = new(big.Int).Set(g.floorPrice)
+	}
+	g.gasPrice = nextInt
+}
+
+// GetGasPrice returns the currently tracked price.
+func (g *GasPricer) GetGasPrice() *big.Int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return new(big.Int).Set(g.gasPrice)
+}
+
+// throughputGasPriceUpdater is the original strategy: each epoch it sums
+// the gas used by the L2 blocks produced since the last epoch boundary
+// and feeds the resulting gas-per-second rate to a GasPricer.
+type throughputGasPriceUpdater struct {
+	gasPricer                    *GasPricer
+	epochStartBlockNumber        uint64
+	averageBlockGasLimitPerEpoch float64
+	epochLengthSeconds           uint64
+	getLatestBlockNumberFn       GetLatestBlockNumberFn
+	getGasUsedByBlockFn          GetGasUsedByBlockFn
+	updateL2GasPriceFn           UpdateL2GasPriceFn
+}
+
+// NewGasPriceUpdater creates a GasPriceUpdater that runs the target-
+// throughput strategy.
+func NewGasPriceUpdater(
+	gasPricer *GasPricer,
+	epochStartBlockNumber uint64,
+	averageBlockGasLimitPerEpoch float64,
+	epochLengthSeconds uint64,
+	getLatestBlockNumberFn GetLatestBlockNumberFn,
+	getGasUsedByBlockFn GetGasUsedByBlockFn,
+	updateL2GasPriceFn UpdateL2GasPriceFn,
+) (GasPriceUpdater, error) {
+	if epochLengthSeconds == 0 {
+		return nil, errors.New("epochLengthSeconds cannot be 0")
+	}
+	return &throughputGasPriceUpdater{
+		gasPricer:                    gasPricer,
+		epochStartBlockNumber:        epochStartBlockNumber,
+		averageBlockGasLimitPerEpoch: averageBlockGasLimitPerEpoch,
+		epochLengthSeconds:           epochLengthSeconds,
+		getLatestBlockNumberFn:       getLatestBlockNumberFn,
+		getGasUsedByBlockFn:          getGasUsedByBlockFn,
+		updateL2GasPriceFn:           updateL2GasPriceFn,
+	}, nil
+}
+
+// UpdateGasPrice implements GasPriceUpdater.
+func (t *throughputGasPriceUpdater) UpdateGasPrice() error {
+	latest, err := t.getLatestBlockNumberFn()
+	if err != nil {
+		return fmt.Errorf("cannot get latest block number: %w", err)
+	}
+	if latest < t.epochStartBlockNumber {
+		return nil
+	}
+
+	var gasUsed uint64
+	for number := t.epochStartBlockNumber; number <= latest; number++ {
+		used, err := t.getGasUsedByBlockFn(number)
+		if err != nil {
+			return fmt.Errorf("cannot get gas used for block %d: %w", number, err)
+		}
+		gasUsed += used
+	}
+
+	gasPerSecond := float64(gasUsed) / float64(t.epochLengthSeconds)
+	t.gasPricer.UpdateGasPrice(gasPerSecond)
+	t.epochStartBlockNumber = latest + 1
+
+	return t.updateL2GasPriceFn(t.gasPricer.GetGasPrice())
+}
+
+// GetGasPrice implements GasPriceUpdater.
+func (t *throughputGasPriceUpdater) GetGasPrice() *big.Int {
+	return t.gasPricer.GetGasPrice()
+}
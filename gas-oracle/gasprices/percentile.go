@@ -0,0 +1,168 @@
+package gasprices
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// percentileGasPriceUpdater mirrors the classic eth-mainnet GPO: it walks
+// the last `blocks` L2 blocks backwards from the tip, collects the
+// effective gas price of every included transaction, and suggests the
+// price at `percentile` of the sorted sample, clamped to
+// [floorPrice, maxPrice]. An update is only pushed on-chain when the
+// suggestion differs from the current price by more than
+// maxPercentChangePerEpoch.
+type percentileGasPriceUpdater struct {
+	mu sync.RWMutex
+
+	currentPrice *big.Int
+	floorPrice   *big.Int
+	maxPrice     *big.Int
+
+	blocks         uint64
+	percentile     uint64
+	maxEmptyBlocks uint64
+
+	maxPercentChangePerEpoch float64
+
+	headerByNumberFn      HeaderByNumberFn
+	transactionsByBlockFn TransactionsByBlockFn
+	updateL2GasPriceFn    UpdateL2GasPriceFn
+}
+
+// NewPercentileGasPriceUpdater creates a GasPriceUpdater that runs the
+// percentile strategy.
+func NewPercentileGasPriceUpdater(
+	currentPrice uint64,
+	floorPrice uint64,
+	maxPrice uint64,
+	blocks uint64,
+	percentile uint64,
+	maxEmptyBlocks uint64,
+	maxPercentChangePerEpoch float64,
+	headerByNumberFn HeaderByNumberFn,
+	transactionsByBlockFn TransactionsByBlockFn,
+	updateL2GasPriceFn UpdateL2GasPriceFn,
+) (GasPriceUpdater, error) {
+	if percentile > 100 {
+		return nil, fmt.Errorf("gpoPercentile must be in [0, 100], got %d", percentile)
+	}
+	if blocks == 0 {
+		return nil, fmt.Errorf("gpoBlocks cannot be 0")
+	}
+	return &percentileGasPriceUpdater{
+		currentPrice:             new(big.Int).SetUint64(currentPrice),
+		floorPrice:               new(big.Int).SetUint64(floorPrice),
+		maxPrice:                 new(big.Int).SetUint64(maxPrice),
+		blocks:                   blocks,
+		percentile:               percentile,
+		maxEmptyBlocks:           maxEmptyBlocks,
+		maxPercentChangePerEpoch: maxPercentChangePerEpoch,
+		headerByNumberFn:         headerByNumberFn,
+		transactionsByBlockFn:    transactionsByBlockFn,
+		updateL2GasPriceFn:       updateL2GasPriceFn,
+	}, nil
+}
+
+// UpdateGasPrice implements GasPriceUpdater.
+func (p *percentileGasPriceUpdater) UpdateGasPrice() error {
+	tip, err := p.headerByNumberFn(nil)
+	if err != nil {
+		return fmt.Errorf("cannot fetch tip header: %w", err)
+	}
+
+	var samples []*big.Int
+	var prevBlockSamples []*big.Int
+	var emptyBlocksSkipped uint64
+
+	number := new(big.Int).Set(tip.Number)
+	for collected := uint64(0); collected < p.blocks && number.Sign() > 0; collected++ {
+		header, err := p.headerByNumberFn(number)
+		if err != nil {
+			return fmt.Errorf("cannot fetch header %s: %w", number, err)
+		}
+		txs, err := p.transactionsByBlockFn(header.Hash())
+		if err != nil {
+			return fmt.Errorf("cannot fetch transactions for block %s: %w", number, err)
+		}
+
+		blockSamples := make([]*big.Int, 0, len(txs))
+		for _, tx := range txs {
+			blockSamples = append(blockSamples, tx.GasPrice())
+		}
+
+		if len(blockSamples) == 0 {
+			if emptyBlocksSkipped < p.maxEmptyBlocks {
+				emptyBlocksSkipped++
+				blockSamples = prevBlockSamples
+			}
+		} else {
+			prevBlockSamples = blockSamples
+			emptyBlocksSkipped = 0
+		}
+
+		samples = append(samples, blockSamples...)
+		number = new(big.Int).Sub(number, big.NewInt(1))
+	}
+
+	if len(samples) == 0 {
+		log.Trace("percentile gas price updater: no transactions sampled, skipping epoch")
+		return nil
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Cmp(samples[j]) < 0 })
+	index := len(samples) * int(p.percentile) / 100
+	if index >= len(samples) {
+		index = len(samples) - 1
+	}
+	suggested := new(big.Int).Set(samples[index])
+
+	if suggested.Cmp(p.floorPrice) < 0 {
+		suggested = new(big.Int).Set(p.floorPrice)
+	}
+	if suggested.Cmp(p.maxPrice) > 0 {
+		suggested = new(big.Int).Set(p.maxPrice)
+	}
+
+	p.mu.RLock()
+	current := p.currentPrice
+	p.mu.RUnlock()
+
+	if !percentileChangeExceeds(current, suggested, p.maxPercentChangePerEpoch) {
+		return nil
+	}
+
+	if err := p.updateL2GasPriceFn(suggested); err != nil {
+		return fmt.Errorf("cannot update l2 gas price: %w", err)
+	}
+
+	p.mu.Lock()
+	p.currentPrice = suggested
+	p.mu.Unlock()
+
+	return nil
+}
+
+// GetGasPrice implements GasPriceUpdater.
+func (p *percentileGasPriceUpdater) GetGasPrice() *big.Int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return new(big.Int).Set(p.currentPrice)
+}
+
+// percentileChangeExceeds reports whether suggested differs from current
+// by more than maxPercentChange.
+func percentileChangeExceeds(current, suggested *big.Int, maxPercentChange float64) bool {
+	if current.Sign() == 0 {
+		return suggested.Sign() != 0
+	}
+	diff := new(big.Float).Sub(new(big.Float).SetInt(suggested), new(big.Float).SetInt(current))
+	diff.Abs(diff)
+	ratio := new(big.Float).Quo(diff, new(big.Float).SetInt(current))
+	threshold := big.NewFloat(maxPercentChange)
+	return ratio.Cmp(threshold) > 0
+}
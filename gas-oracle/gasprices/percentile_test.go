@@ -0,0 +1,140 @@
+package gasprices
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func txWithGasPrice(price int64) *types.Transaction {
+	to := common.Address{}
+	return types.NewTx(&types.LegacyTx{
+		GasPrice: big.NewInt(price),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(0),
+	})
+}
+
+// blockFixture wires up a small chain of fake blocks for
+// percentileGasPriceUpdater to walk backwards from the tip, keyed by block
+// number and addressed by header hash the way the real
+// TransactionsByBlockFn is.
+type blockFixture struct {
+	tipNumber uint64
+	headers   map[uint64]*types.Header
+	txs       map[common.Hash]types.Transactions
+}
+
+func newBlockFixture(blocks map[uint64][]int64) *blockFixture {
+	f := &blockFixture{headers: make(map[uint64]*types.Header), txs: make(map[common.Hash]types.Transactions)}
+	for number, prices := range blocks {
+		if number > f.tipNumber {
+			f.tipNumber = number
+		}
+		header := &types.Header{Number: big.NewInt(int64(number)), Difficulty: big.NewInt(0)}
+		f.headers[number] = header
+		txs := make(types.Transactions, 0, len(prices))
+		for _, p := range prices {
+			txs = append(txs, txWithGasPrice(p))
+		}
+		f.txs[header.Hash()] = txs
+	}
+	return f
+}
+
+func (f *blockFixture) headerByNumberFn(number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return f.headers[f.tipNumber], nil
+	}
+	return f.headers[number.Uint64()], nil
+}
+
+func (f *blockFixture) transactionsByBlockFn(hash common.Hash) (types.Transactions, error) {
+	return f.txs[hash], nil
+}
+
+func TestPercentileGasPriceUpdaterIndexMath(t *testing.T) {
+	// Ten samples across two blocks, prices 1..10. The 60th percentile of
+	// a 10-element sorted sample is index 10*60/100 = 6, i.e. the
+	// 7th-smallest price.
+	fixture := newBlockFixture(map[uint64][]int64{
+		2: {6, 7, 8, 9, 10},
+		1: {1, 2, 3, 4, 5},
+	})
+
+	var published *big.Int
+	updater, err := NewPercentileGasPriceUpdater(
+		1, 0, 1000, 2, 60, 0, 1.0,
+		fixture.headerByNumberFn, fixture.transactionsByBlockFn,
+		func(gasPrice *big.Int) error { published = gasPrice; return nil },
+	)
+	if err != nil {
+		t.Fatalf("NewPercentileGasPriceUpdater: %v", err)
+	}
+	if err := updater.UpdateGasPrice(); err != nil {
+		t.Fatalf("UpdateGasPrice: %v", err)
+	}
+	if want := big.NewInt(7); published == nil || published.Cmp(want) != 0 {
+		t.Fatalf("published price = %v, want %s", published, want)
+	}
+}
+
+func TestPercentileGasPriceUpdaterClampsToFloorAndMax(t *testing.T) {
+	fixture := newBlockFixture(map[uint64][]int64{1: {5000}})
+
+	updater, err := NewPercentileGasPriceUpdater(
+		1, 100, 2000, 1, 50, 0, 1.0,
+		fixture.headerByNumberFn, fixture.transactionsByBlockFn,
+		func(gasPrice *big.Int) error { return nil },
+	)
+	if err != nil {
+		t.Fatalf("NewPercentileGasPriceUpdater: %v", err)
+	}
+	if err := updater.UpdateGasPrice(); err != nil {
+		t.Fatalf("UpdateGasPrice: %v", err)
+	}
+	if want := big.NewInt(2000); updater.GetGasPrice().Cmp(want) != 0 {
+		t.Fatalf("GetGasPrice() = %s, want %s (clamped to gpoMaxPrice)", updater.GetGasPrice(), want)
+	}
+}
+
+func TestPercentileGasPriceUpdaterReusesPreviousBlockOnEmpty(t *testing.T) {
+	// Block 1 (older than the tip) is empty; with gpoMaxEmpty >= 1 it
+	// should reuse the tip block's samples instead of contributing
+	// nothing, so the suggested price still reflects block 2's prices
+	// rather than being computed over a smaller, skewed sample.
+	fixture := newBlockFixture(map[uint64][]int64{
+		2: {10, 20, 30},
+		1: {},
+	})
+
+	var published *big.Int
+	updater, err := NewPercentileGasPriceUpdater(
+		1, 0, 1000, 2, 50, 1, 1.0,
+		fixture.headerByNumberFn, fixture.transactionsByBlockFn,
+		func(gasPrice *big.Int) error { published = gasPrice; return nil },
+	)
+	if err != nil {
+		t.Fatalf("NewPercentileGasPriceUpdater: %v", err)
+	}
+	if err := updater.UpdateGasPrice(); err != nil {
+		t.Fatalf("UpdateGasPrice: %v", err)
+	}
+	// Six total samples (block 2's three, reused for block 1): sorted
+	// [10,10,20,20,30,30], 50th percentile index 6*50/100=3 -> 20.
+	if want := big.NewInt(20); published == nil || published.Cmp(want) != 0 {
+		t.Fatalf("published price = %v, want %s (block 1 reusing block 2's samples)", published, want)
+	}
+}
+
+func TestNewPercentileGasPriceUpdaterRejectsInvalidConfig(t *testing.T) {
+	if _, err := NewPercentileGasPriceUpdater(0, 0, 0, 0, 50, 0, 0, nil, nil, nil); err == nil {
+		t.Fatal("expected an error when gpoBlocks is 0")
+	}
+	if _, err := NewPercentileGasPriceUpdater(0, 0, 0, 1, 101, 0, 0, nil, nil, nil); err == nil {
+		t.Fatal("expected an error when gpoPercentile exceeds 100")
+	}
+}
@@ -0,0 +1,125 @@
+package gasprices
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// stepGasPriceUpdater mirrors the original Ethereum GasPriceOracle step
+// algorithm: each epoch it looks at how full the L2 blocks produced since
+// the last tick were, in aggregate, relative to their gas limit, and
+// nudges a running price up or down by a fixed percentage, then applies a
+// correction factor and hard-clamps the result. Unlike the throughput
+// strategy's percent-change-per-epoch limiter, this produces a smooth,
+// congestion-driven curve rather than a reactive one tied to a target
+// rate.
+type stepGasPriceUpdater struct {
+	mu sync.RWMutex
+
+	gasPrice    *big.Int
+	minGasPrice *big.Int
+	maxGasPrice *big.Int
+
+	stepUp           float64
+	stepDown         float64
+	fullBlockRatio   float64
+	correctionFactor float64
+
+	lastBlockNumber        uint64
+	getLatestBlockNumberFn GetLatestBlockNumberFn
+	headerByNumberFn       HeaderByNumberFn
+	updateL2GasPriceFn     UpdateL2GasPriceFn
+}
+
+// NewStepGasPriceUpdater creates a GasPriceUpdater that runs the step
+// strategy.
+func NewStepGasPriceUpdater(
+	currentPrice uint64,
+	minGasPrice uint64,
+	maxGasPrice uint64,
+	stepUp float64,
+	stepDown float64,
+	fullBlockRatio float64,
+	correctionFactor float64,
+	epochStartBlockNumber uint64,
+	getLatestBlockNumberFn GetLatestBlockNumberFn,
+	headerByNumberFn HeaderByNumberFn,
+	updateL2GasPriceFn UpdateL2GasPriceFn,
+) (GasPriceUpdater, error) {
+	if minGasPrice > maxGasPrice {
+		return nil, fmt.Errorf("gpoMinGasPrice (%d) cannot exceed gpoMaxGasPrice (%d)", minGasPrice, maxGasPrice)
+	}
+	return &stepGasPriceUpdater{
+		gasPrice:               new(big.Int).SetUint64(currentPrice),
+		minGasPrice:            new(big.Int).SetUint64(minGasPrice),
+		maxGasPrice:            new(big.Int).SetUint64(maxGasPrice),
+		stepUp:                 stepUp,
+		stepDown:               stepDown,
+		fullBlockRatio:         fullBlockRatio,
+		correctionFactor:       correctionFactor,
+		lastBlockNumber:        epochStartBlockNumber,
+		getLatestBlockNumberFn: getLatestBlockNumberFn,
+		headerByNumberFn:       headerByNumberFn,
+		updateL2GasPriceFn:     updateL2GasPriceFn,
+	}, nil
+}
+
+// UpdateGasPrice implements GasPriceUpdater.
+func (s *stepGasPriceUpdater) UpdateGasPrice() error {
+	latest, err := s.getLatestBlockNumberFn()
+	if err != nil {
+		return fmt.Errorf("cannot get latest block number: %w", err)
+	}
+	if latest < s.lastBlockNumber {
+		return nil
+	}
+
+	// Aggregate fullness across every block since the last tick, not just
+	// the tip, so a single quiet or congested block at the tick boundary
+	// can't mask or fake out an epoch's worth of activity; mirrors how
+	// throughputGasPriceUpdater sums gas used over the same range.
+	var gasUsed, gasLimit uint64
+	for number := s.lastBlockNumber; number <= latest; number++ {
+		header, err := s.headerByNumberFn(new(big.Int).SetUint64(number))
+		if err != nil {
+			return fmt.Errorf("cannot fetch header %d: %w", number, err)
+		}
+		gasUsed += header.GasUsed
+		gasLimit += header.GasLimit
+	}
+	s.lastBlockNumber = latest + 1
+	if gasLimit == 0 {
+		return nil
+	}
+
+	ratio := float64(gasUsed) / float64(gasLimit)
+
+	s.mu.Lock()
+	price := new(big.Float).SetInt(s.gasPrice)
+	if ratio > s.fullBlockRatio {
+		price.Mul(price, big.NewFloat(1+s.stepUp/100))
+	} else if ratio < s.fullBlockRatio {
+		price.Mul(price, big.NewFloat(1-s.stepDown/100))
+	}
+	price.Mul(price, big.NewFloat(s.correctionFactor))
+
+	next, _ := price.Int(nil)
+	if next.Cmp(s.minGasPrice) < 0 {
+		next = new(big.Int).Set(s.minGasPrice)
+	}
+	if next.Cmp(s.maxGasPrice) > 0 {
+		next = new(big.Int).Set(s.maxGasPrice)
+	}
+	s.gasPrice = next
+	s.mu.Unlock()
+
+	return s.updateL2GasPriceFn(next)
+}
+
+// GetGasPrice implements GasPriceUpdater.
+func (s *stepGasPriceUpdater) GetGasPrice() *big.Int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return new(big.Int).Set(s.gasPrice)
+}
@@ -0,0 +1,108 @@
+package gasprices
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func fakeStepHeaderFn(header *types.Header) HeaderByNumberFn {
+	return func(number *big.Int) (*types.Header, error) { return header, nil }
+}
+
+func TestStepGasPriceUpdaterStepsUpOnFullBlocks(t *testing.T) {
+	header := &types.Header{GasUsed: 29_000_000, GasLimit: 30_000_000} // ~97% full
+	var published *big.Int
+	updater, err := NewStepGasPriceUpdater(
+		1000, 1, 1_000_000, 10, 10, 0.9, 1.0, 5,
+		func() (uint64, error) { return 5, nil },
+		fakeStepHeaderFn(header),
+		func(gasPrice *big.Int) error { published = gasPrice; return nil },
+	)
+	if err != nil {
+		t.Fatalf("NewStepGasPriceUpdater: %v", err)
+	}
+	if err := updater.UpdateGasPrice(); err != nil {
+		t.Fatalf("UpdateGasPrice: %v", err)
+	}
+	want := big.NewInt(1100)
+	if published == nil || published.Cmp(want) != 0 {
+		t.Fatalf("published price = %v, want %s (1000 stepped up 10%%)", published, want)
+	}
+	if got := updater.GetGasPrice(); got.Cmp(want) != 0 {
+		t.Fatalf("GetGasPrice() = %s, want %s", got, want)
+	}
+}
+
+func TestStepGasPriceUpdaterStepsDownOnEmptyBlocks(t *testing.T) {
+	header := &types.Header{GasUsed: 1_000_000, GasLimit: 30_000_000} // ~3% full
+	updater, err := NewStepGasPriceUpdater(
+		1000, 1, 1_000_000, 10, 10, 0.9, 1.0, 5,
+		func() (uint64, error) { return 5, nil },
+		fakeStepHeaderFn(header),
+		func(gasPrice *big.Int) error { return nil },
+	)
+	if err != nil {
+		t.Fatalf("NewStepGasPriceUpdater: %v", err)
+	}
+	if err := updater.UpdateGasPrice(); err != nil {
+		t.Fatalf("UpdateGasPrice: %v", err)
+	}
+	if want := big.NewInt(900); updater.GetGasPrice().Cmp(want) != 0 {
+		t.Fatalf("GetGasPrice() = %s, want %s (1000 stepped down 10%%)", updater.GetGasPrice(), want)
+	}
+}
+
+func TestStepGasPriceUpdaterClampsToMaxGasPrice(t *testing.T) {
+	header := &types.Header{GasUsed: 29_000_000, GasLimit: 30_000_000}
+	updater, err := NewStepGasPriceUpdater(
+		1000, 0, 1050, 50, 10, 0.9, 1.0, 5,
+		func() (uint64, error) { return 5, nil },
+		fakeStepHeaderFn(header),
+		func(gasPrice *big.Int) error { return nil },
+	)
+	if err != nil {
+		t.Fatalf("NewStepGasPriceUpdater: %v", err)
+	}
+	if err := updater.UpdateGasPrice(); err != nil {
+		t.Fatalf("UpdateGasPrice: %v", err)
+	}
+	if want := big.NewInt(1050); updater.GetGasPrice().Cmp(want) != 0 {
+		t.Fatalf("GetGasPrice() = %s, want %s (clamped to gpoMaxGasPrice)", updater.GetGasPrice(), want)
+	}
+}
+
+func TestStepGasPriceUpdaterAggregatesAcrossEpochRange(t *testing.T) {
+	// Blocks 7-9 are nearly full; block 10 (the tip) is only 80% full, so
+	// sampling the tip alone would read as not-full (below fullBlockRatio)
+	// and step down. Aggregated over the whole range the epoch is still
+	// above fullBlockRatio and should step up instead.
+	headers := map[uint64]*types.Header{
+		7:  {GasUsed: 29_000_000, GasLimit: 30_000_000},
+		8:  {GasUsed: 29_000_000, GasLimit: 30_000_000},
+		9:  {GasUsed: 29_000_000, GasLimit: 30_000_000},
+		10: {GasUsed: 24_000_000, GasLimit: 30_000_000},
+	}
+	updater, err := NewStepGasPriceUpdater(
+		1000, 1, 1_000_000, 10, 10, 0.9, 1.0, 7,
+		func() (uint64, error) { return 10, nil },
+		func(number *big.Int) (*types.Header, error) { return headers[number.Uint64()], nil },
+		func(gasPrice *big.Int) error { return nil },
+	)
+	if err != nil {
+		t.Fatalf("NewStepGasPriceUpdater: %v", err)
+	}
+	if err := updater.UpdateGasPrice(); err != nil {
+		t.Fatalf("UpdateGasPrice: %v", err)
+	}
+	if want := big.NewInt(1100); updater.GetGasPrice().Cmp(want) != 0 {
+		t.Fatalf("GetGasPrice() = %s, want %s (stepped up from aggregate fullness, not the 80%%-full tip block alone)", updater.GetGasPrice(), want)
+	}
+}
+
+func TestNewStepGasPriceUpdaterRejectsInvertedBounds(t *testing.T) {
+	if _, err := NewStepGasPriceUpdater(0, 100, 10, 0, 0, 0, 1, 0, nil, nil, nil); err == nil {
+		t.Fatal("expected an error when gpoMinGasPrice exceeds gpoMaxGasPrice")
+	}
+}
@@ -0,0 +1,448 @@
+// Package multiclient provides multi-endpoint failover for the RPC
+// clients the gas-oracle depends on. Instead of a single *ethclient.Client
+// per layer, Client dials a list of endpoints, round-robins requests
+// across the ones it currently considers healthy, and demotes an endpoint
+// to unhealthy after too many consecutive failures, too high an error
+// rate, or too high an average latency over its recent sliding window of
+// calls. Reads of the chain
+// tip additionally require a quorum of endpoints to agree on the block
+// number, which guards against a forked or lagging RPC silently pushing a
+// bogus gas price on-chain.
+package multiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// unhealthyThreshold is the number of consecutive failures after
+	// which a node is marked unhealthy.
+	unhealthyThreshold = 3
+	// reprobeInterval is how long an unhealthy node is left alone before
+	// it is tried again.
+	reprobeInterval = 30 * time.Second
+	// healthWindowSize is the number of most recent calls each node
+	// tracks latency and success/failure for, used to compute an error
+	// rate that can flag a flaky-but-not-consecutively-failing node
+	// before unhealthyThreshold would.
+	healthWindowSize = 20
+	// healthWindowMinSamples is the number of samples required before the
+	// sliding-window error rate is trusted; avoids a node that just
+	// started taking traffic being marked unhealthy off one bad call.
+	healthWindowMinSamples = 5
+	// healthWindowErrorRateThreshold marks a node unhealthy once its
+	// error rate over the sliding window reaches this fraction, even if
+	// its failures weren't consecutive.
+	healthWindowErrorRateThreshold = 0.5
+	// healthWindowLatencyThreshold marks a node unhealthy once its
+	// average latency over the sliding window reaches this, even if it's
+	// still succeeding — a node this slow stalls every loop that picks it
+	// just as surely as one that's failing outright.
+	healthWindowLatencyThreshold = 2 * time.Second
+)
+
+// ErrNoQuorum is returned when fewer than the configured quorum of
+// endpoints agree on the latest block number.
+var ErrNoQuorum = errors.New("multiclient: no quorum on latest block number")
+
+// ErrNoHealthyNodes is returned when every endpoint is currently marked
+// unhealthy.
+var ErrNoHealthyNodes = errors.New("multiclient: no healthy endpoints")
+
+// healthSample is one call's outcome, kept in a node's sliding window.
+type healthSample struct {
+	success bool
+	latency time.Duration
+}
+
+// node tracks the health of a single dialed endpoint. Three independent
+// signals can mark it unhealthy: a run of consecutive failures (fast to
+// trip, forgiving of an isolated blip), the error rate over its sliding
+// window of recent calls (catches an endpoint that's failing often but
+// not consecutively, e.g. every other request), and its average latency
+// over that same window (catches an endpoint that's still answering but
+// too slowly to be worth picking).
+type node struct {
+	url    string
+	client *ethclient.Client
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	lastFailureAt       time.Time
+
+	samples   [healthWindowSize]healthSample
+	sampleNum int
+	sampleLen int
+}
+
+// healthy reports whether the node should still be picked. The
+// consecutive-failure trip is only honored for reprobeInterval after the
+// last failure, after which the node is given another chance regardless
+// of its past streak; the sliding-window checks have no such timeout and
+// are re-evaluated on every call, since a bad patch rolls off the window
+// on its own as fresh samples replace it.
+func (n *node) healthy() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.consecutiveFailures >= unhealthyThreshold && time.Since(n.lastFailureAt) < reprobeInterval {
+		return false
+	}
+	if n.sampleLen >= healthWindowMinSamples {
+		if n.errorRateLocked() >= healthWindowErrorRateThreshold {
+			return false
+		}
+		if n.avgLatencyLocked() >= healthWindowLatencyThreshold {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *node) recordResult(err error, latency time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err == nil {
+		n.consecutiveFailures = 0
+	} else {
+		n.consecutiveFailures++
+		n.lastFailureAt = time.Now()
+	}
+
+	idx := n.sampleNum % healthWindowSize
+	n.samples[idx] = healthSample{success: err == nil, latency: latency}
+	n.sampleNum++
+	if n.sampleLen < healthWindowSize {
+		n.sampleLen++
+	}
+}
+
+// errorRateLocked returns the fraction of failed calls across the node's
+// sliding window of up to healthWindowSize samples. Callers must hold n.mu.
+func (n *node) errorRateLocked() float64 {
+	var failures int
+	for i := 0; i < n.sampleLen; i++ {
+		if !n.samples[i].success {
+			failures++
+		}
+	}
+	return float64(failures) / float64(n.sampleLen)
+}
+
+// avgLatencyLocked returns the mean latency across the node's sliding
+// window of up to healthWindowSize samples. Callers must hold n.mu.
+func (n *node) avgLatencyLocked() time.Duration {
+	if n.sampleLen == 0 {
+		return 0
+	}
+	var total time.Duration
+	for i := 0; i < n.sampleLen; i++ {
+		total += n.samples[i].latency
+	}
+	return total / time.Duration(n.sampleLen)
+}
+
+// Client is a DeployContractBackend-compatible client that spreads its
+// calls across several endpoints.
+type Client struct {
+	mu     sync.Mutex
+	nodes  []*node
+	next   int
+	quorum int
+}
+
+// Dial connects to every url and returns a Client that fails over between
+// them. quorum is the number of endpoints that must agree on the latest
+// block number before it is trusted; it is clamped to at least 1 and at
+// most len(urls).
+func Dial(urls []string, quorum int) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("multiclient: no endpoints configured")
+	}
+	if quorum < 1 {
+		quorum = 1
+	}
+	if quorum > len(urls) {
+		quorum = len(urls)
+	}
+
+	nodes := make([]*node, 0, len(urls))
+	for _, url := range urls {
+		client, err := ethclient.Dial(url)
+		if err != nil {
+			return nil, fmt.Errorf("cannot dial %s: %w", url, err)
+		}
+		nodes = append(nodes, &node{url: url, client: client})
+	}
+
+	return &Client{nodes: nodes, quorum: quorum}, nil
+}
+
+// pick returns the next healthy node in round-robin order.
+func (c *Client) pick() (*node, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := 0; i < len(c.nodes); i++ {
+		idx := (c.next + i) % len(c.nodes)
+		if c.nodes[idx].healthy() {
+			c.next = (idx + 1) % len(c.nodes)
+			return c.nodes[idx], nil
+		}
+	}
+	return nil, ErrNoHealthyNodes
+}
+
+// ChainID returns the chain ID, probing nodes in round-robin order until
+// one answers.
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	var lastErr error
+	for i := 0; i < len(c.nodes); i++ {
+		n, err := c.pick()
+		if err != nil {
+			if lastErr == nil {
+				lastErr = err
+			}
+			break
+		}
+		start := time.Now()
+		id, err := n.client.ChainID(ctx)
+		n.recordResult(err, time.Since(start))
+		if err == nil {
+			return id, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// HeaderByNumber returns the header at number. When number is nil (the
+// chain tip), it additionally requires quorum of the healthy nodes to
+// agree on the latest block number before returning it.
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number != nil {
+		n, err := c.pick()
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		header, err := n.client.HeaderByNumber(ctx, number)
+		n.recordResult(err, time.Since(start))
+		return header, err
+	}
+	return c.quorumTipHeader(ctx)
+}
+
+// quorumTipHeader polls every healthy node for its tip and returns the
+// most common header once at least c.quorum nodes agree on the block
+// number.
+func (c *Client) quorumTipHeader(ctx context.Context) (*types.Header, error) {
+	c.mu.Lock()
+	nodes := append([]*node{}, c.nodes...)
+	c.mu.Unlock()
+
+	counts := make(map[uint64]int)
+	headers := make(map[uint64]*types.Header)
+	for _, n := range nodes {
+		if !n.healthy() {
+			continue
+		}
+		start := time.Now()
+		header, err := n.client.HeaderByNumber(ctx, nil)
+		n.recordResult(err, time.Since(start))
+		if err != nil {
+			log.Warn("multiclient: tip header probe failed", "url", n.url, "err", err)
+			continue
+		}
+		number := header.Number.Uint64()
+		counts[number]++
+		headers[number] = header
+	}
+
+	best, bestCount, ok := quorumWinner(counts, c.quorum)
+	if !ok {
+		return nil, fmt.Errorf("%w: got %d/%d agreeing on block %d", ErrNoQuorum, bestCount, c.quorum, best)
+	}
+	return headers[best], nil
+}
+
+// quorumWinner picks the block number with the most nodes agreeing on it,
+// breaking ties by preferring the higher number (the more advanced tip),
+// and reports whether at least quorum nodes agreed on the winner.
+func quorumWinner(counts map[uint64]int, quorum int) (number uint64, count int, ok bool) {
+	for n, c := range counts {
+		if c > count || (c == count && n > number) {
+			number = n
+			count = c
+		}
+	}
+	return number, count, count >= quorum
+}
+
+// BlockByHash returns the block with the given hash.
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	n, err := c.pick()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	block, err := n.client.BlockByHash(ctx, hash)
+	n.recordResult(err, time.Since(start))
+	return block, err
+}
+
+// SuggestGasPrice returns the suggested gas price reported by the next
+// healthy node.
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	n, err := c.pick()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	price, err := n.client.SuggestGasPrice(ctx)
+	n.recordResult(err, time.Since(start))
+	return price, err
+}
+
+// SuggestGasTipCap returns the suggested priority fee reported by the
+// next healthy node.
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	n, err := c.pick()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	tip, err := n.client.SuggestGasTipCap(ctx)
+	n.recordResult(err, time.Since(start))
+	return tip, err
+}
+
+// PendingCodeAt returns the pending code at account from the next healthy
+// node.
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	n, err := c.pick()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	code, err := n.client.PendingCodeAt(ctx, account)
+	n.recordResult(err, time.Since(start))
+	return code, err
+}
+
+// PendingNonceAt returns the pending nonce of account from the next
+// healthy node.
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	n, err := c.pick()
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	nonce, err := n.client.PendingNonceAt(ctx, account)
+	n.recordResult(err, time.Since(start))
+	return nonce, err
+}
+
+// EstimateGas estimates the gas required by call against the next healthy
+// node.
+func (c *Client) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	n, err := c.pick()
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	gas, err := n.client.EstimateGas(ctx, call)
+	n.recordResult(err, time.Since(start))
+	return gas, err
+}
+
+// SendTransaction broadcasts tx to every currently healthy node so that a
+// single stalled endpoint can't silently swallow an update.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	nodes := append([]*node{}, c.nodes...)
+	c.mu.Unlock()
+
+	var lastErr error
+	sent := 0
+	for _, n := range nodes {
+		if !n.healthy() {
+			continue
+		}
+		start := time.Now()
+		err := n.client.SendTransaction(ctx, tx)
+		n.recordResult(err, time.Since(start))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		sent++
+	}
+	if sent == 0 {
+		if lastErr == nil {
+			lastErr = ErrNoHealthyNodes
+		}
+		return lastErr
+	}
+	return nil
+}
+
+// CodeAt returns the code of account at the given block from the next
+// healthy node.
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	n, err := c.pick()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	code, err := n.client.CodeAt(ctx, account, blockNumber)
+	n.recordResult(err, time.Since(start))
+	return code, err
+}
+
+// CallContract executes an eth_call against the next healthy node.
+func (c *Client) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	n, err := c.pick()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	out, err := n.client.CallContract(ctx, call, blockNumber)
+	n.recordResult(err, time.Since(start))
+	return out, err
+}
+
+// FilterLogs returns the logs matching query from the next healthy node.
+func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	n, err := c.pick()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	logs, err := n.client.FilterLogs(ctx, query)
+	n.recordResult(err, time.Since(start))
+	return logs, err
+}
+
+// SubscribeFilterLogs subscribes to logs matching query on the next
+// healthy node.
+func (c *Client) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	n, err := c.pick()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	sub, err := n.client.SubscribeFilterLogs(ctx, query, ch)
+	n.recordResult(err, time.Since(start))
+	return sub, err
+}
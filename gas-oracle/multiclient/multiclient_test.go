@@ -0,0 +1,121 @@
+package multiclient
+
+import "testing"
+
+func TestQuorumWinner(t *testing.T) {
+	tests := []struct {
+		name       string
+		counts     map[uint64]int
+		quorum     int
+		wantNumber uint64
+		wantCount  int
+		wantOK     bool
+	}{
+		{
+			name:       "single block meets quorum",
+			counts:     map[uint64]int{100: 3},
+			quorum:     2,
+			wantNumber: 100,
+			wantCount:  3,
+			wantOK:     true,
+		},
+		{
+			name:       "tie breaks toward the higher block number",
+			counts:     map[uint64]int{100: 2, 101: 2},
+			quorum:     2,
+			wantNumber: 101,
+			wantCount:  2,
+			wantOK:     true,
+		},
+		{
+			name:       "a lagging node's stale block never wins over a fresher tie",
+			counts:     map[uint64]int{99: 2, 101: 2, 100: 1},
+			quorum:     2,
+			wantNumber: 101,
+			wantCount:  2,
+			wantOK:     true,
+		},
+		{
+			name:       "no candidate reaches quorum",
+			counts:     map[uint64]int{100: 1, 101: 1},
+			quorum:     2,
+			wantNumber: 101,
+			wantCount:  1,
+			wantOK:     false,
+		},
+		{
+			name:   "no candidates at all",
+			counts: map[uint64]int{},
+			quorum: 1,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, count, ok := quorumWinner(tt.counts, tt.quorum)
+			if number != tt.wantNumber || count != tt.wantCount || ok != tt.wantOK {
+				t.Fatalf("quorumWinner(%v, %d) = (%d, %d, %v), want (%d, %d, %v)",
+					tt.counts, tt.quorum, number, count, ok, tt.wantNumber, tt.wantCount, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNodeHealthySlidingWindowErrorRate(t *testing.T) {
+	n := &node{}
+
+	// A handful of failures spread out (never hitting unhealthyThreshold
+	// consecutively) should still trip the sliding-window error rate once
+	// enough samples have been collected.
+	for i := 0; i < healthWindowMinSamples; i++ {
+		if !n.healthy() {
+			t.Fatalf("node should still be healthy before crossing healthWindowErrorRateThreshold (iteration %d)", i)
+		}
+		if i%2 == 0 {
+			n.recordResult(errSentinel, 0)
+		} else {
+			n.recordResult(nil, 0)
+		}
+	}
+	if n.healthy() {
+		t.Fatal("node with a >=50% error rate over the sliding window should be unhealthy")
+	}
+}
+
+func TestNodeHealthyConsecutiveFailureThreshold(t *testing.T) {
+	n := &node{}
+	for i := 0; i < unhealthyThreshold-1; i++ {
+		n.recordResult(errSentinel, 0)
+		if !n.healthy() {
+			t.Fatalf("node should stay healthy below unhealthyThreshold (iteration %d)", i)
+		}
+	}
+	n.recordResult(errSentinel, 0)
+	if n.healthy() {
+		t.Fatal("node should be unhealthy after unhealthyThreshold consecutive failures")
+	}
+}
+
+func TestNodeHealthySlidingWindowLatency(t *testing.T) {
+	n := &node{}
+
+	// Every call succeeds, but each is slower than
+	// healthWindowLatencyThreshold; the node should still trip unhealthy
+	// once enough samples have been collected.
+	for i := 0; i < healthWindowMinSamples; i++ {
+		if !n.healthy() {
+			t.Fatalf("node should still be healthy before crossing healthWindowLatencyThreshold (iteration %d)", i)
+		}
+		n.recordResult(nil, healthWindowLatencyThreshold*2)
+	}
+	if n.healthy() {
+		t.Fatal("node with a high average latency over the sliding window should be unhealthy")
+	}
+}
+
+var errSentinel = &sentinelError{}
+
+type sentinelError struct{}
+
+func (*sentinelError) Error() string { return "sentinel" }
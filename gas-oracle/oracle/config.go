@@ -0,0 +1,142 @@
+package oracle
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GasPriceStrategy selects which gasprices.GasPriceUpdater implementation
+// the oracle drives its L2 gas price updates with.
+type GasPriceStrategy string
+
+const (
+	// StrategyTargetThroughput is the original strategy: it reacts to the
+	// observed gas-per-second on L2 relative to a configured target.
+	StrategyTargetThroughput GasPriceStrategy = "target-throughput"
+	// StrategyPercentile samples recent L2 blocks and suggests a price at
+	// a configurable percentile of the observed effective gas prices,
+	// mirroring the classic eth-mainnet GPO.
+	StrategyPercentile GasPriceStrategy = "percentile"
+	// StrategyStep steps a running price up or down by a fixed percentage
+	// depending on how full the L2 blocks since the last epoch were in
+	// aggregate, then applies a correction factor and hard min/max bounds.
+	StrategyStep GasPriceStrategy = "step"
+)
+
+// Config represents the configuration options available to the gas-oracle
+type Config struct {
+	ethereumHttpUrl string
+	layerTwoHttpUrl string
+
+	// L1RpcUrls and L2RpcUrls, when non-empty, put the corresponding
+	// client behind a multiclient.Client that fails over between the
+	// listed endpoints instead of dialing the single *HttpUrl above.
+	// RpcQuorum is the number of those endpoints that must agree on the
+	// latest block number before an epoch is allowed to advance.
+	L1RpcUrls []string
+	L2RpcUrls []string
+	RpcQuorum int
+
+	gasPriceOracleAddress common.Address
+	daFeeContractAddress  common.Address
+
+	privateKey *ecdsa.PrivateKey
+	EnableHsm  bool
+	HsmAddress string
+
+	l1ChainID *big.Int
+	l2ChainID *big.Int
+
+	floorPrice                   uint64
+	targetGasPerSecond           uint64
+	maxPercentChangePerEpoch     float64
+	averageBlockGasLimitPerEpoch float64
+	epochLengthSeconds           uint64
+
+	l1BaseFeeEpochLengthSeconds uint64
+	daFeeEpochLengthSeconds     uint64
+
+	enableL1BaseFee  bool
+	enableL2GasPrice bool
+	enableDaFee      bool
+
+	PriceBackendURL                  string
+	tokenPricerUpdateFrequencySecond uint64
+
+	// gasPriceStrategy selects the gasprices.GasPriceUpdater implementation
+	// used by Loop. Defaults to StrategyTargetThroughput.
+	gasPriceStrategy GasPriceStrategy
+
+	// gpoBlocks is the number of most recent L2 blocks sampled by the
+	// percentile strategy.
+	gpoBlocks uint64
+	// gpoPercentile is the percentile (0-100) of sampled effective gas
+	// prices suggested by the percentile strategy.
+	gpoPercentile uint64
+	// gpoMaxEmpty bounds how many consecutive empty blocks the percentile
+	// strategy fills in by reusing the previously sampled block's prices;
+	// further consecutive empty blocks contribute no samples.
+	gpoMaxEmpty uint64
+	// gpoMaxPrice clamps the high end of the percentile strategy's
+	// suggested price, mirroring floorPrice on the low end.
+	gpoMaxPrice uint64
+
+	// gpoRewardPercentile is the per-block percentile (0-100) of
+	// effective priority fees sampled when publishing the EIP-1559 base
+	// fee / priority fee decomposition for 1559-capable L2s.
+	gpoRewardPercentile uint64
+	// gpoEnable1559 opts in to the EIP-1559 base fee / priority fee
+	// decomposition in wrapUpdateL2GasPriceFn. It defaults to false so
+	// that every gasPriceStrategy (target-throughput, percentile, step)
+	// keeps publishing its own computed price via the legacy SetGasPrice
+	// path even on a 1559-capable L2; without this gate that computed
+	// price is silently discarded in favor of the decomposition the
+	// moment the L2 reports a base fee.
+	gpoEnable1559 bool
+
+	// gpoStepUp and gpoStepDown are the percentage the step strategy
+	// moves its running price by each epoch, depending on which side of
+	// gpoFullBlockRatio the summed gasUsed/gasLimit of the blocks since the
+	// last epoch falls.
+	gpoStepUp   float64
+	gpoStepDown float64
+	// gpoFullBlockRatio is the gasUsed/gasLimit threshold that decides
+	// whether the step strategy steps its price up or down.
+	gpoFullBlockRatio float64
+	// gpoCorrectionFactor is applied to the step strategy's price every
+	// epoch, after the step, to let operators bias the curve up or down.
+	gpoCorrectionFactor float64
+	// gpoMinGasPrice and gpoMaxGasPrice hard-clamp the step strategy's
+	// price.
+	gpoMinGasPrice uint64
+	gpoMaxGasPrice uint64
+
+	// l2Sink, l1BaseFeeSink, and daFeeSink each select the PriceSink that
+	// their respective loop publishes proposed prices to. Valid values
+	// are "" or "onchain" (submit the transaction, the original
+	// behavior), "log", "prometheus", and "webhook". Non-onchain values
+	// let an operator shadow-run a configuration against production data
+	// before trusting it to write on-chain.
+	l2Sink        string
+	l1BaseFeeSink string
+	daFeeSink     string
+	// SinkWebhookURL is the URL a "webhook" sink POSTs proposed prices to.
+	SinkWebhookURL string
+
+	// l1FeeCombineMode selects how NewGasPriceOracle combines the
+	// suggestions of more than one enabled l1fee.L1FeeEstimator. "" (the
+	// default) keeps the original first-non-nil-suggestion-wins behavior
+	// in wrapUpdateL1Fee; "max", "min", and "weighted" instead wrap every
+	// enabled estimator in a single l1fee.CompositeEstimator so operators
+	// can blend e.g. several DA fee sources without a rebuild.
+	l1FeeCombineMode string
+	// l1FeeWeights are the per-estimator weights used when
+	// l1FeeCombineMode is "weighted", matched positionally to the order
+	// estimators are constructed in NewGasPriceOracle (the L1 base fee
+	// estimator first, then the DA fee estimator). Ignored otherwise. The
+	// weights apply to a fee component only when every estimator returns a
+	// value for it; otherwise that component is averaged unweighted.
+	l1FeeWeights []float64
+}
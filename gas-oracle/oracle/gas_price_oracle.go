@@ -14,6 +14,8 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/mantlenetworkio/mantle/gas-oracle/bindings"
 	"github.com/mantlenetworkio/mantle/gas-oracle/gasprices"
+	"github.com/mantlenetworkio/mantle/gas-oracle/multiclient"
+	"github.com/mantlenetworkio/mantle/gas-oracle/oracle/l1fee"
 	"github.com/mantlenetworkio/mantle/gas-oracle/tokenprice"
 )
 
@@ -43,9 +45,10 @@ type GasPriceOracle struct {
 	stop            chan struct{}
 	contract        *bindings.BVMGasPriceOracle
 	l2Backend       DeployContractBackend
-	l1Backend       bind.ContractTransactor
-	daBackend       *bindings.BVMEigenDataLayrFee
-	gasPriceUpdater *gasprices.GasPriceUpdater
+	l1Backend       DeployContractBackend
+	estimators      []l1fee.L1FeeEstimator
+	gasPriceUpdater gasprices.GasPriceUpdater
+	updateL1Fee     func() error
 	config          *Config
 }
 
@@ -81,11 +84,8 @@ func (g *GasPriceOracle) Start() error {
 	log.Info("Starting Gas Price Oracle enableL1BaseFee", "enableL1BaseFee",
 		g.config.enableL1BaseFee, "enableL2GasPrice", g.config.enableL2GasPrice, "enableDaFee", g.config.enableDaFee)
 
-	if g.config.enableL1BaseFee {
-		go g.BaseFeeLoop()
-	}
-	if g.config.enableDaFee {
-		go g.DaFeeLoop()
+	if g.config.enableL1BaseFee || g.config.enableDaFee {
+		go g.L1FeeLoop()
 	}
 	if g.config.enableL2GasPrice {
 		go g.Loop()
@@ -104,8 +104,13 @@ func (g *GasPriceOracle) Wait() {
 
 // ensure makes sure that the configured private key is the owner
 // of the `BVM_GasPriceOracle`. If it is not the owner, then it will
-// not be able to make updates to the L2 gas price.
+// not be able to make updates to the L2 gas price. This check is skipped
+// when every enabled loop is configured with a non-onchain PriceSink,
+// since nothing will actually be transacted in that case.
 func (g *GasPriceOracle) ensure() error {
+	if !g.needsOnChainAuth() {
+		return nil
+	}
 	owner, err := g.contract.Owner(&bind.CallOpts{
 		Context: g.ctx,
 	})
@@ -125,6 +130,23 @@ func (g *GasPriceOracle) ensure() error {
 	return nil
 }
 
+// needsOnChainAuth reports whether any enabled loop is configured to
+// actually write on-chain, and therefore whether ensure needs to verify
+// the signing key owns the contract.
+func (g *GasPriceOracle) needsOnChainAuth() bool {
+	cfg := g.config
+	if cfg.enableL2GasPrice && isOnChainSink(cfg.l2Sink) {
+		return true
+	}
+	if cfg.enableL1BaseFee && isOnChainSink(cfg.l1BaseFeeSink) {
+		return true
+	}
+	if cfg.enableDaFee && isOnChainSink(cfg.daFeeSink) {
+		return true
+	}
+	return false
+}
+
 // Loop is the main logic of the gas-oracle
 func (g *GasPriceOracle) Loop() {
 	timer := time.NewTicker(time.Duration(g.config.epochLengthSeconds) * time.Second)
@@ -144,42 +166,24 @@ func (g *GasPriceOracle) Loop() {
 	}
 }
 
-func (g *GasPriceOracle) BaseFeeLoop() {
+// L1FeeLoop periodically asks every configured l1fee.L1FeeEstimator for
+// its suggested fee components and pushes them to the L2 contract. It
+// replaces the old split BaseFeeLoop/DaFeeLoop pair now that both run the
+// same estimate-then-publish shape behind a single interface.
+//
+// g.updateL1Fee is built and validated by NewGasPriceOracle so that a bad
+// l1BaseFeeSink/daFeeSink configuration fails at startup the same way a
+// bad l2Sink does, instead of this loop panicking the first time it fires.
+func (g *GasPriceOracle) L1FeeLoop() {
 	timer := time.NewTicker(time.Duration(g.config.l1BaseFeeEpochLengthSeconds) * time.Second)
 	defer timer.Stop()
 
-	updateBaseFee, err := wrapUpdateBaseFee(g.l1Backend, g.l2Backend, g.config)
-	if err != nil {
-		panic(err)
-	}
-	for {
-		select {
-		case <-timer.C:
-			if err := updateBaseFee(); err != nil {
-				log.Error("cannot update l1 base fee", "message", err)
-			}
-		case <-g.ctx.Done():
-			g.Stop()
-		}
-	}
-}
-
-func (g *GasPriceOracle) DaFeeLoop() {
-	timer := time.NewTicker(time.Duration(g.config.daFeeEpochLengthSeconds) * time.Second)
-	defer timer.Stop()
-
-	updateDaFee, err := wrapUpdateDaFee(g.daBackend, g.l2Backend, g.config)
-	if err != nil {
-		panic(err)
-	}
-
 	for {
 		select {
 		case <-timer.C:
-			if err := updateDaFee(); err != nil {
-				log.Error("cannot update da fee", "messgae", err)
+			if err := g.updateL1Fee(); err != nil {
+				log.Error("cannot update l1 fee", "message", err)
 			}
-
 		case <-g.ctx.Done():
 			g.Stop()
 		}
@@ -217,17 +221,29 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 	if tokenPricer == nil {
 		return nil, fmt.Errorf("invalid token price client")
 	}
-	// Create the L2 client
-	l2Client, err := ethclient.Dial(cfg.layerTwoHttpUrl)
+	// Create the L2 client. When L2RpcUrls is configured, fail over across
+	// the listed endpoints instead of dialing the single configured URL.
+	var l2Client DeployContractBackend
+	var err error
+	if len(cfg.L2RpcUrls) > 0 {
+		l2Client, err = multiclient.Dial(cfg.L2RpcUrls, cfg.RpcQuorum)
+	} else {
+		l2Client, err = ethclient.Dial(cfg.layerTwoHttpUrl)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	l1Client, err := NewL1Client(cfg.ethereumHttpUrl, tokenPricer)
+	var l1Client *L1Client
+	if len(cfg.L1RpcUrls) > 0 {
+		l1Client, err = NewMultiL1Client(cfg.L1RpcUrls, cfg.RpcQuorum, tokenPricer)
+	} else {
+		l1Client, err = NewL1Client(cfg.ethereumHttpUrl, tokenPricer)
+	}
 	if err != nil {
 		return nil, err
 	}
-	daFeeClient, err := bindings.NewBVMEigenDataLayrFee(cfg.daFeeContractAddress, l1Client.Client)
+	daFeeClient, err := bindings.NewBVMEigenDataLayrFee(cfg.daFeeContractAddress, l1Client.DeployContractBackend)
 	// Ensure that we can actually connect to both backends
 	log.Info("Connecting to layer two")
 	if err := ensureConnection(l2Client); err != nil {
@@ -235,11 +251,36 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 		return nil, err
 	}
 	log.Info("Connecting to layer one")
-	if err := ensureConnection(l1Client.Client); err != nil {
+	if err := ensureConnection(l1Client); err != nil {
 		log.Error("Unable to connect to layer one")
 		return nil, err
 	}
 
+	// Build the set of L1FeeEstimators that L1FeeLoop will poll. Each
+	// enabled data source contributes one estimator; additional DA layers
+	// can be added here without touching the loop itself.
+	var estimators []l1fee.L1FeeEstimator
+	if cfg.enableL1BaseFee {
+		estimators = append(estimators, l1fee.NewEthL1Estimator(l1Client))
+	}
+	if cfg.enableDaFee {
+		estimators = append(estimators, l1fee.NewEigenDAEstimator(daFeeClient))
+	}
+	if len(estimators) > 1 {
+		switch cfg.l1FeeCombineMode {
+		case "", "first":
+			// Keep wrapUpdateL1Fee's first-non-nil-wins behavior below.
+		case "max":
+			estimators = []l1fee.L1FeeEstimator{l1fee.NewCompositeEstimator(l1fee.Max, estimators...)}
+		case "min":
+			estimators = []l1fee.L1FeeEstimator{l1fee.NewCompositeEstimator(l1fee.Min, estimators...)}
+		case "weighted":
+			estimators = []l1fee.L1FeeEstimator{l1fee.NewCompositeEstimator(l1fee.WeightedAverage(cfg.l1FeeWeights), estimators...)}
+		default:
+			return nil, fmt.Errorf("unknown l1 fee combine mode %q", cfg.l1FeeCombineMode)
+		}
+	}
+
 	address := cfg.gasPriceOracleAddress
 	contract, err := bindings.NewBVMGasPriceOracle(address, l2Client)
 	if err != nil {
@@ -254,24 +295,6 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 		return nil, err
 	}
 
-	// Create a gas pricer for the gas price updater
-	log.Info("Creating GasPricer", "currentPrice", currentPrice,
-		"floorPrice", cfg.floorPrice, "targetGasPerSecond", cfg.targetGasPerSecond,
-		"maxPercentChangePerEpoch", cfg.maxPercentChangePerEpoch)
-
-	gasPricer, err := gasprices.NewGasPricer(
-		currentPrice.Uint64(),
-		cfg.floorPrice,
-		tokenPricer,
-		func() float64 {
-			return float64(cfg.targetGasPerSecond)
-		},
-		cfg.maxPercentChangePerEpoch,
-	)
-	if err != nil {
-		return nil, err
-	}
-
 	l2ChainID, err := l2Client.ChainID(context.Background())
 	if err != nil {
 		return nil, err
@@ -322,21 +345,73 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 	// getGasUsedByBlockFn is used by the GasPriceUpdater
 	// to fetch the amount of gas that a block has used
 	getGasUsedByBlockFn := wrapGetGasUsedByBlock(l2Client)
+	// updateL1Fee is used by L1FeeLoop; building it here, like
+	// updateL2GasPriceFn above, validates cfg.l1BaseFeeSink/daFeeSink
+	// eagerly instead of panicking the first time the loop fires.
+	updateL1Fee, err := wrapUpdateL1Fee(estimators, l2Client, cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	log.Info("Creating GasPriceUpdater", "epochStartBlockNumber", epochStartBlockNumber,
+	log.Info("Creating GasPriceUpdater", "strategy", cfg.gasPriceStrategy,
+		"epochStartBlockNumber", epochStartBlockNumber,
 		"averageBlockGasLimitPerEpoch", cfg.averageBlockGasLimitPerEpoch,
 		"epochLengthSeconds", cfg.epochLengthSeconds)
 
-	gasPriceUpdater, err := gasprices.NewGasPriceUpdater(
-		gasPricer,
-		epochStartBlockNumber,
-		cfg.averageBlockGasLimitPerEpoch,
-		cfg.epochLengthSeconds,
-		getLatestBlockNumberFn,
-		getGasUsedByBlockFn,
-		updateL2GasPriceFn,
-	)
-
+	var gasPriceUpdater gasprices.GasPriceUpdater
+	switch cfg.gasPriceStrategy {
+	case "", StrategyTargetThroughput:
+		gasPricer, err := gasprices.NewGasPricer(
+			currentPrice.Uint64(),
+			cfg.floorPrice,
+			tokenPricer,
+			func() float64 {
+				return float64(cfg.targetGasPerSecond)
+			},
+			cfg.maxPercentChangePerEpoch,
+		)
+		if err != nil {
+			return nil, err
+		}
+		gasPriceUpdater, err = gasprices.NewGasPriceUpdater(
+			gasPricer,
+			epochStartBlockNumber,
+			cfg.averageBlockGasLimitPerEpoch,
+			cfg.epochLengthSeconds,
+			getLatestBlockNumberFn,
+			getGasUsedByBlockFn,
+			updateL2GasPriceFn,
+		)
+	case StrategyPercentile:
+		gasPriceUpdater, err = gasprices.NewPercentileGasPriceUpdater(
+			currentPrice.Uint64(),
+			cfg.floorPrice,
+			cfg.gpoMaxPrice,
+			cfg.gpoBlocks,
+			cfg.gpoPercentile,
+			cfg.gpoMaxEmpty,
+			cfg.maxPercentChangePerEpoch,
+			wrapHeaderByNumberFn(l2Client),
+			wrapTransactionsByBlockFn(l2Client),
+			updateL2GasPriceFn,
+		)
+	case StrategyStep:
+		gasPriceUpdater, err = gasprices.NewStepGasPriceUpdater(
+			currentPrice.Uint64(),
+			cfg.gpoMinGasPrice,
+			cfg.gpoMaxGasPrice,
+			cfg.gpoStepUp,
+			cfg.gpoStepDown,
+			cfg.gpoFullBlockRatio,
+			cfg.gpoCorrectionFactor,
+			epochStartBlockNumber,
+			getLatestBlockNumberFn,
+			wrapHeaderByNumberFn(l2Client),
+			updateL2GasPriceFn,
+		)
+	default:
+		return nil, fmt.Errorf("unknown gas price strategy %q", cfg.gasPriceStrategy)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -348,10 +423,11 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 		stop:            make(chan struct{}),
 		contract:        contract,
 		gasPriceUpdater: gasPriceUpdater,
+		updateL1Fee:     updateL1Fee,
 		config:          cfg,
 		l2Backend:       l2Client,
 		l1Backend:       l1Client,
-		daBackend:       daFeeClient,
+		estimators:      estimators,
 	}
 
 	if err := gpo.ensure(); err != nil {
@@ -362,7 +438,9 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 }
 
 // Ensure that we can actually connect
-func ensureConnection(client *ethclient.Client) error {
+func ensureConnection(client interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+}) error {
 	t := time.NewTicker(1 * time.Second)
 	retries := 0
 	defer t.Stop()
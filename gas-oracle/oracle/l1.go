@@ -0,0 +1,152 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/mantlenetworkio/mantle/gas-oracle/bindings"
+	"github.com/mantlenetworkio/mantle/gas-oracle/multiclient"
+	"github.com/mantlenetworkio/mantle/gas-oracle/oracle/l1fee"
+	"github.com/mantlenetworkio/mantle/gas-oracle/tokenprice"
+)
+
+// L1Client wraps the RPC connection to layer one, backed by either a
+// single *ethclient.Client or a *multiclient.Client spanning several
+// endpoints. It exists mainly so that a tokenprice.Client can be carried
+// alongside the RPC connection without threading it through every call
+// site.
+type L1Client struct {
+	DeployContractBackend
+	tokenPricer *tokenprice.Client
+}
+
+// NewL1Client dials the given layer-one RPC endpoint.
+func NewL1Client(url string, tokenPricer *tokenprice.Client) (*L1Client, error) {
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	return &L1Client{DeployContractBackend: client, tokenPricer: tokenPricer}, nil
+}
+
+// NewMultiL1Client dials every given layer-one RPC endpoint and returns a
+// client that fails over between them, requiring quorum of them to agree
+// on the latest block number whenever the layer-one chain tip is read.
+func NewMultiL1Client(urls []string, quorum int, tokenPricer *tokenprice.Client) (*L1Client, error) {
+	client, err := multiclient.Dial(urls, quorum)
+	if err != nil {
+		return nil, err
+	}
+	return &L1Client{DeployContractBackend: client, tokenPricer: tokenPricer}, nil
+}
+
+// wrapUpdateL1Fee returns a function that asks every configured
+// l1fee.L1FeeEstimator for its suggestion and publishes whichever fee
+// components come back non-nil: baseFee and blobBaseFee via
+// cfg.l1BaseFeeSink, daFee via cfg.daFeeSink. When more than one estimator
+// is enabled, NewGasPriceOracle has already collapsed them into a single
+// l1fee.CompositeEstimator per cfg.l1FeeCombineMode; the first-non-nil-wins
+// behavior below only applies when l1FeeCombineMode is left at its default
+// and estimators are passed through individually.
+func wrapUpdateL1Fee(estimators []l1fee.L1FeeEstimator, l2Backend DeployContractBackend, cfg *Config) (func() error, error) {
+	contract, err := bindings.NewBVMGasPriceOracle(cfg.gasPriceOracleAddress, l2Backend)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := newTransactOpts(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	baseFeeSink, err := newConfiguredSink(cfg.l1BaseFeeSink, cfg.SinkWebhookURL, NewOnChainSink(func(update PriceUpdate) error {
+		switch update.Component {
+		case "l1BaseFee":
+			tx, err := contract.SetL1BaseFee(opts, update.Proposed)
+			if err != nil {
+				return fmt.Errorf("cannot set l1 base fee: %w", err)
+			}
+			log.Info("Updated L1 base fee", "tx", tx.Hash().Hex(), "baseFee", update.Proposed)
+		case "blobBaseFee":
+			tx, err := contract.SetBlobBaseFee(opts, update.Proposed)
+			if err != nil {
+				return fmt.Errorf("cannot set l1 blob base fee: %w", err)
+			}
+			log.Info("Updated L1 blob base fee", "tx", tx.Hash().Hex(), "blobBaseFee", update.Proposed)
+		}
+		return nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	daFeeSink, err := newConfiguredSink(cfg.daFeeSink, cfg.SinkWebhookURL, NewOnChainSink(func(update PriceUpdate) error {
+		tx, err := contract.SetDAFee(opts, update.Proposed)
+		if err != nil {
+			return fmt.Errorf("cannot set da fee: %w", err)
+		}
+		log.Info("Updated DA fee", "tx", tx.Hash().Hex(), "daFee", update.Proposed)
+		return nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		var baseFee, blobBaseFee, daFee *big.Int
+		for _, estimator := range estimators {
+			b, blob, da, err := estimator.Suggest(context.Background())
+			if err != nil {
+				log.Warn("l1 fee estimator failed", "err", err)
+				continue
+			}
+			if baseFee == nil {
+				baseFee = b
+			}
+			if blobBaseFee == nil {
+				blobBaseFee = blob
+			}
+			if daFee == nil {
+				daFee = da
+			}
+		}
+
+		now := time.Now().Unix()
+		if baseFee != nil {
+			update := PriceUpdate{Layer: "l1", Component: "l1BaseFee", Current: currentOnChainValue(contract.L1BaseFee), Proposed: baseFee, Timestamp: now}
+			if err := baseFeeSink.Publish(update); err != nil {
+				return fmt.Errorf("cannot publish l1 base fee: %w", err)
+			}
+		}
+		if blobBaseFee != nil {
+			update := PriceUpdate{Layer: "l1", Component: "blobBaseFee", Current: currentOnChainValue(contract.BlobBaseFee), Proposed: blobBaseFee, Timestamp: now}
+			if err := baseFeeSink.Publish(update); err != nil {
+				return fmt.Errorf("cannot publish l1 blob base fee: %w", err)
+			}
+		}
+		if daFee != nil {
+			update := PriceUpdate{Layer: "l1", Component: "daFee", Current: currentOnChainValue(contract.DAFee), Proposed: daFee, Timestamp: now}
+			if err := daFeeSink.Publish(update); err != nil {
+				return fmt.Errorf("cannot publish da fee: %w", err)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// currentOnChainValue calls a BVM_GasPriceOracle getter to fetch the value
+// a PriceUpdate is about to propose replacing, for sinks (LogSink,
+// WebhookSink) that report proposed-vs-current. A failed read is logged
+// and left as nil rather than failing the whole update, since the
+// suggestion itself is still worth publishing.
+func currentOnChainValue(get func(opts *bind.CallOpts) (*big.Int, error)) *big.Int {
+	value, err := get(&bind.CallOpts{Context: context.Background()})
+	if err != nil {
+		log.Warn("cannot fetch current on-chain value for price update", "err", err)
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,108 @@
+package l1fee
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// CombineFn reduces the non-nil suggestions collected for a single fee
+// component down to one value.
+type CombineFn func(values []*big.Int) *big.Int
+
+// Max returns the largest value.
+func Max(values []*big.Int) *big.Int {
+	return reduce(values, func(a, b *big.Int) bool { return b.Cmp(a) > 0 })
+}
+
+// Min returns the smallest value.
+func Min(values []*big.Int) *big.Int {
+	return reduce(values, func(a, b *big.Int) bool { return b.Cmp(a) < 0 })
+}
+
+func reduce(values []*big.Int, replace func(current, candidate *big.Int) bool) *big.Int {
+	if len(values) == 0 {
+		return nil
+	}
+	best := values[0]
+	for _, v := range values[1:] {
+		if replace(best, v) {
+			best = v
+		}
+	}
+	return new(big.Int).Set(best)
+}
+
+// WeightedAverage returns a CombineFn that averages its inputs using the
+// given weights, matched to inputs by position. If the number of values
+// doesn't match the number of weights (an estimator returned no opinion),
+// the values are averaged unweighted instead.
+func WeightedAverage(weights []float64) CombineFn {
+	return func(values []*big.Int) *big.Int {
+		if len(values) == 0 {
+			return nil
+		}
+		if len(values) != len(weights) {
+			return unweightedAverage(values)
+		}
+		sum := new(big.Float)
+		var totalWeight float64
+		for i, v := range values {
+			weighted := new(big.Float).Mul(new(big.Float).SetInt(v), big.NewFloat(weights[i]))
+			sum.Add(sum, weighted)
+			totalWeight += weights[i]
+		}
+		if totalWeight == 0 {
+			return unweightedAverage(values)
+		}
+		avg := new(big.Float).Quo(sum, big.NewFloat(totalWeight))
+		result, _ := avg.Int(nil)
+		return result
+	}
+}
+
+func unweightedAverage(values []*big.Int) *big.Int {
+	sum := new(big.Int)
+	for _, v := range values {
+		sum.Add(sum, v)
+	}
+	return sum.Div(sum, big.NewInt(int64(len(values))))
+}
+
+// CompositeEstimator runs several estimators and reduces their
+// suggestions for each fee component independently with combine, letting
+// operators run e.g. max-of-several DA estimators or a weighted-average
+// blend of L1 base fee sources.
+type CompositeEstimator struct {
+	estimators []L1FeeEstimator
+	combine    CombineFn
+}
+
+// NewCompositeEstimator creates a CompositeEstimator over estimators,
+// reducing per-component suggestions with combine.
+func NewCompositeEstimator(combine CombineFn, estimators ...L1FeeEstimator) *CompositeEstimator {
+	return &CompositeEstimator{estimators: estimators, combine: combine}
+}
+
+// Suggest implements L1FeeEstimator.
+func (c *CompositeEstimator) Suggest(ctx context.Context) (baseFee, blobBaseFee, daFee *big.Int, err error) {
+	var baseFees, blobBaseFees, daFees []*big.Int
+	for _, estimator := range c.estimators {
+		b, blob, da, err := estimator.Suggest(ctx)
+		if err != nil {
+			log.Warn("l1fee: composite sub-estimator failed", "err", err)
+			continue
+		}
+		if b != nil {
+			baseFees = append(baseFees, b)
+		}
+		if blob != nil {
+			blobBaseFees = append(blobBaseFees, blob)
+		}
+		if da != nil {
+			daFees = append(daFees, da)
+		}
+	}
+	return c.combine(baseFees), c.combine(blobBaseFees), c.combine(daFees), nil
+}
@@ -0,0 +1,32 @@
+package l1fee
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/mantlenetworkio/mantle/gas-oracle/bindings"
+)
+
+// EigenDAEstimator suggests the DA fee by reading the current data price
+// from the BVM_EigenDataLayrFee contract. This is the estimator the
+// gas-oracle has always used for EigenDA; it now just implements
+// L1FeeEstimator instead of being wired in directly.
+type EigenDAEstimator struct {
+	daBackend *bindings.BVMEigenDataLayrFee
+}
+
+// NewEigenDAEstimator creates an EigenDAEstimator backed by daBackend.
+func NewEigenDAEstimator(daBackend *bindings.BVMEigenDataLayrFee) *EigenDAEstimator {
+	return &EigenDAEstimator{daBackend: daBackend}
+}
+
+// Suggest implements L1FeeEstimator. It never suggests a base fee or blob
+// base fee.
+func (e *EigenDAEstimator) Suggest(ctx context.Context) (baseFee, blobBaseFee, daFee *big.Int, err error) {
+	fee, err := e.daBackend.DataPrice(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return nil, nil, fee, nil
+}
@@ -0,0 +1,76 @@
+package l1fee
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errNoBaseFee is returned when the connected L1 has not yet activated
+// EIP-1559 and therefore has no base fee to estimate against.
+var errNoBaseFee = errors.New("l1fee: connected L1 chain has no base fee")
+
+// EthClient is the subset of *ethclient.Client that EthL1Estimator needs.
+type EthClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// EthL1Estimator suggests the L1 base fee and blob base fee by reading
+// them straight off the L1 chain tip, the same fields wallets read via
+// eth_feeHistory / eth_blobBaseFee.
+type EthL1Estimator struct {
+	client EthClient
+}
+
+// NewEthL1Estimator creates an EthL1Estimator backed by client.
+func NewEthL1Estimator(client EthClient) *EthL1Estimator {
+	return &EthL1Estimator{client: client}
+}
+
+// Suggest implements L1FeeEstimator. It never suggests a DA fee.
+func (e *EthL1Estimator) Suggest(ctx context.Context) (baseFee, blobBaseFee, daFee *big.Int, err error) {
+	header, err := e.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if header.BaseFee == nil {
+		return nil, nil, nil, errNoBaseFee
+	}
+	baseFee = new(big.Int).Set(header.BaseFee)
+
+	if header.ExcessBlobGas != nil {
+		blobBaseFee = calcBlobBaseFee(*header.ExcessBlobGas)
+	}
+	return baseFee, blobBaseFee, nil, nil
+}
+
+// minBlobBaseFee and blobBaseFeeUpdateFraction mirror the EIP-4844
+// constants used to derive the blob base fee from excess blob gas.
+const (
+	minBlobBaseFee            = 1
+	blobBaseFeeUpdateFraction = 3338477
+)
+
+// calcBlobBaseFee implements the EIP-4844 fake-exponential used to derive
+// the blob base fee from a header's excess blob gas.
+func calcBlobBaseFee(excessBlobGas uint64) *big.Int {
+	return fakeExponential(big.NewInt(minBlobBaseFee), new(big.Int).SetUint64(excessBlobGas), big.NewInt(blobBaseFeeUpdateFraction))
+}
+
+// fakeExponential approximates factor * e**(numerator/denominator), as
+// defined by EIP-4844.
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	i := big.NewInt(1)
+	output := new(big.Int)
+	numeratorAccum := new(big.Int).Mul(factor, denominator)
+	for numeratorAccum.Sign() > 0 {
+		output.Add(output, numeratorAccum)
+		numeratorAccum.Mul(numeratorAccum, numerator)
+		numeratorAccum.Div(numeratorAccum, denominator)
+		numeratorAccum.Div(numeratorAccum, i)
+		i.Add(i, big.NewInt(1))
+	}
+	return output.Div(output, denominator)
+}
@@ -0,0 +1,20 @@
+// Package l1fee decouples the gas-oracle's L1 fee update loop from any
+// particular data-availability layer. Every fee component the L2 needs
+// priced against L1 (the execution base fee, the blob base fee, and a DA
+// layer's own fee) is estimated behind the same L1FeeEstimator interface,
+// so new DA layers can be added by writing a new estimator rather than
+// duplicating loop scaffolding.
+package l1fee
+
+import (
+	"context"
+	"math/big"
+)
+
+// L1FeeEstimator suggests the L1-priced fee components that the
+// gas-oracle publishes to the L2 BVM_GasPriceOracle contract. Any
+// returned value may be nil, meaning that estimator has no opinion on
+// that component and the caller should leave the on-chain value as-is.
+type L1FeeEstimator interface {
+	Suggest(ctx context.Context) (baseFee, blobBaseFee, daFee *big.Int, err error)
+}
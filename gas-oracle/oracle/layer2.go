@@ -0,0 +1,289 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/mantlenetworkio/mantle/gas-oracle/bindings"
+	"github.com/mantlenetworkio/mantle/gas-oracle/gasprices"
+)
+
+const (
+	// baseFeeChangeDenominator bounds how much the base fee can move per
+	// block, matching the EIP-1559 constant used by L1.
+	baseFeeChangeDenominator = 8
+	// elasticityMultiplier is the ratio between a block's gas limit and
+	// its long-run gas target, matching the EIP-1559 constant used by L1.
+	elasticityMultiplier = 2
+)
+
+// oracle1559Setter is implemented by BVM_GasPriceOracle bindings that
+// expose the EIP-1559 base fee / priority fee decomposition. Contracts
+// that predate the decomposition only implement the legacy SetGasPrice
+// path, so callers type-assert for this interface before using it.
+type oracle1559Setter interface {
+	SetSuggestedBaseFee(opts *bind.TransactOpts, baseFee *big.Int) (*types.Transaction, error)
+	SetSuggestedPriorityFee(opts *bind.TransactOpts, priorityFee *big.Int) (*types.Transaction, error)
+}
+
+// oracle1559Getter is implemented by BVM_GasPriceOracle bindings that
+// expose the current on-chain suggested priority fee, used only to
+// populate PriceUpdate.Current for sinks that report proposed-vs-current;
+// its absence doesn't block publishing the decomposition.
+type oracle1559Getter interface {
+	SuggestedPriorityFee(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// DeployContractBackend represents the union of methods needed to read
+// and transact against the L2 BVM_GasPriceOracle contract, plus the
+// header/block/fee reads the gas-oracle's own loops need. It is
+// satisfied by both *ethclient.Client and *multiclient.Client, so the
+// oracle can be pointed at either a single endpoint or a failover group
+// without its call sites caring which.
+type DeployContractBackend interface {
+	bind.ContractBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// newTransactOpts builds the bind.TransactOpts used to sign the gas price
+// update transactions, using either the configured hot key or the HSM.
+func newTransactOpts(cfg *Config) (*bind.TransactOpts, error) {
+	if cfg.EnableHsm {
+		return NewHsmTransactOpts(cfg.HsmAddress, cfg.l2ChainID)
+	}
+	return bind.NewKeyedTransactorWithChainID(cfg.privateKey, cfg.l2ChainID)
+}
+
+// wrapGetLatestBlockNumberFn returns a GetLatestBlockNumberFn that reads
+// the latest block number from the given client.
+func wrapGetLatestBlockNumberFn(client DeployContractBackend) gasprices.GetLatestBlockNumberFn {
+	return func() (uint64, error) {
+		header, err := client.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return 0, err
+		}
+		return header.Number.Uint64(), nil
+	}
+}
+
+// wrapGetGasUsedByBlock returns a GetGasUsedByBlockFn that reads the gas
+// used by a given block from the given client.
+func wrapGetGasUsedByBlock(client DeployContractBackend) gasprices.GetGasUsedByBlockFn {
+	return func(number uint64) (uint64, error) {
+		header, err := client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(number))
+		if err != nil {
+			return 0, err
+		}
+		return header.GasUsed, nil
+	}
+}
+
+// wrapHeaderByNumberFn returns a HeaderByNumberFn backed by the given
+// client. A nil number fetches the chain tip, matching ethclient's own
+// convention.
+func wrapHeaderByNumberFn(client DeployContractBackend) gasprices.HeaderByNumberFn {
+	return func(number *big.Int) (*types.Header, error) {
+		return client.HeaderByNumber(context.Background(), number)
+	}
+}
+
+// wrapTransactionsByBlockFn returns a TransactionsByBlockFn backed by the
+// given client.
+func wrapTransactionsByBlockFn(client DeployContractBackend) gasprices.TransactionsByBlockFn {
+	return func(hash common.Hash) (types.Transactions, error) {
+		block, err := client.BlockByHash(context.Background(), hash)
+		if err != nil {
+			return nil, err
+		}
+		return block.Transactions(), nil
+	}
+}
+
+// wrapUpdateL2GasPriceFn returns an UpdateL2GasPriceFn that publishes the
+// suggested gas price via cfg.l2Sink. When cfg.gpoEnable1559 is set, the L2
+// reports EIP-1559 headers, and the contract binding supports it, it
+// instead publishes a base fee / priority fee decomposition: the base fee
+// follows the protocol's own EIP-1559 update rule applied to the tip
+// header, and the priority fee is sampled from recent blocks' effective
+// gas tips via suggestPriorityFee. The passed-in gasPrice - whatever the
+// configured gasPriceStrategy computed - still has an effect on a
+// 1559-capable L2: it caps the combined base + priority fee, so a
+// strategy that wants a lower overall price than the sampled tip would
+// give can still pull it down, without corrupting the priority fee's tip
+// semantics when the strategy's number is the looser bound. Only the
+// priority fee is lowered, and never below zero, so when gasPrice is below
+// the computed base fee the combined fee still exceeds it. It falls back
+// to publishing gasPrice through the legacy single-scalar setter when the
+// decomposition isn't enabled or the L2 hasn't activated 1559 yet.
+func wrapUpdateL2GasPriceFn(client DeployContractBackend, cfg *Config) (gasprices.UpdateL2GasPriceFn, error) {
+	contract, err := bindings.NewBVMGasPriceOracle(cfg.gasPriceOracleAddress, client)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := newTransactOpts(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	setter, supports1559 := interface{}(contract).(oracle1559Setter)
+	headerByNumberFn := wrapHeaderByNumberFn(client)
+	transactionsByBlockFn := wrapTransactionsByBlockFn(client)
+
+	sink, err := newConfiguredSink(cfg.l2Sink, cfg.SinkWebhookURL, NewOnChainSink(func(update PriceUpdate) error {
+		switch update.Component {
+		case "baseFee":
+			tx, err := setter.SetSuggestedBaseFee(opts, update.Proposed)
+			if err != nil {
+				return fmt.Errorf("cannot set suggested base fee: %w", err)
+			}
+			log.Info("Updated L2 1559 base fee", "tx", tx.Hash().Hex(), "baseFee", update.Proposed)
+		case "priorityFee":
+			tx, err := setter.SetSuggestedPriorityFee(opts, update.Proposed)
+			if err != nil {
+				return fmt.Errorf("cannot set suggested priority fee: %w", err)
+			}
+			log.Info("Updated L2 1559 priority fee", "tx", tx.Hash().Hex(), "priorityFee", update.Proposed)
+		case "gasPrice":
+			tx, err := contract.SetGasPrice(opts, update.Proposed)
+			if err != nil {
+				return fmt.Errorf("cannot set l2 gas price: %w", err)
+			}
+			log.Info("Updated L2 gas price", "tx", tx.Hash().Hex(), "gasPrice", update.Proposed)
+		}
+		return nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(gasPrice *big.Int) error {
+		now := time.Now().Unix()
+		if supports1559 && cfg.gpoEnable1559 {
+			tip, err := client.HeaderByNumber(context.Background(), nil)
+			if err != nil {
+				return fmt.Errorf("cannot fetch tip header: %w", err)
+			}
+			if tip.BaseFee != nil {
+				baseFee := calcNextBaseFee(tip)
+				priorityFee, err := suggestPriorityFee(headerByNumberFn, transactionsByBlockFn, cfg.gpoBlocks, cfg.gpoRewardPercentile)
+				if err != nil {
+					log.Warn("cannot sample priority fee tips, falling back to legacy gas price", "err", err)
+				} else {
+					// gasPrice - whatever the configured gasPriceStrategy
+					// computed - caps the combined fee so every strategy
+					// still has an effect here, without overriding the
+					// sampled priorityFee's tip semantics when gasPrice
+					// isn't the binding constraint.
+					if total := new(big.Int).Add(baseFee, priorityFee); total.Cmp(gasPrice) > 0 {
+						priorityFee = new(big.Int).Sub(gasPrice, baseFee)
+						if priorityFee.Sign() < 0 {
+							priorityFee = big.NewInt(0)
+						}
+					}
+					if err := sink.Publish(PriceUpdate{Layer: "l2", Component: "baseFee", Current: tip.BaseFee, Proposed: baseFee, EpochStartBlock: tip.Number.Uint64(), Timestamp: now}); err != nil {
+						return fmt.Errorf("cannot publish suggested base fee: %w", err)
+					}
+					var currentPriorityFee *big.Int
+					if getter, ok := interface{}(contract).(oracle1559Getter); ok {
+						currentPriorityFee = currentOnChainValue(getter.SuggestedPriorityFee)
+					}
+					if err := sink.Publish(PriceUpdate{Layer: "l2", Component: "priorityFee", Current: currentPriorityFee, Proposed: priorityFee, EpochStartBlock: tip.Number.Uint64(), Timestamp: now}); err != nil {
+						return fmt.Errorf("cannot publish suggested priority fee: %w", err)
+					}
+					return nil
+				}
+			}
+		}
+
+		return sink.Publish(PriceUpdate{Layer: "l2", Component: "gasPrice", Current: currentOnChainValue(contract.GasPrice), Proposed: gasPrice, Timestamp: now})
+	}, nil
+}
+
+// calcNextBaseFee derives the next base fee from a header's gas usage
+// relative to its gas target, following the EIP-1559 update rule.
+func calcNextBaseFee(header *types.Header) *big.Int {
+	gasTarget := header.GasLimit / elasticityMultiplier
+	baseFee := new(big.Int).Set(header.BaseFee)
+	if gasTarget == 0 || header.GasUsed == gasTarget {
+		return baseFee
+	}
+
+	if header.GasUsed > gasTarget {
+		delta := header.GasUsed - gasTarget
+		x := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(delta))
+		change := x.Div(x, new(big.Int).SetUint64(gasTarget))
+		change.Div(change, big.NewInt(baseFeeChangeDenominator))
+		if change.Sign() == 0 {
+			change.SetInt64(1)
+		}
+		return baseFee.Add(baseFee, change)
+	}
+
+	delta := gasTarget - header.GasUsed
+	x := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(delta))
+	change := x.Div(x, new(big.Int).SetUint64(gasTarget))
+	change.Div(change, big.NewInt(baseFeeChangeDenominator))
+	return baseFee.Sub(baseFee, change)
+}
+
+// suggestPriorityFee samples the effective priority tip of transactions
+// in the last `blocks` L2 blocks, taking the per-block `percentile` and
+// returning the median across blocks.
+func suggestPriorityFee(headerByNumberFn gasprices.HeaderByNumberFn, transactionsByBlockFn gasprices.TransactionsByBlockFn, blocks uint64, percentile uint64) (*big.Int, error) {
+	tip, err := headerByNumberFn(nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch tip header: %w", err)
+	}
+
+	var perBlockSamples []*big.Int
+	number := new(big.Int).Set(tip.Number)
+	for collected := uint64(0); collected < blocks && number.Sign() > 0; collected++ {
+		header, err := headerByNumberFn(number)
+		if err != nil {
+			return nil, fmt.Errorf("cannot fetch header %s: %w", number, err)
+		}
+		if header.BaseFee == nil {
+			return nil, errNoBaseFee
+		}
+		txs, err := transactionsByBlockFn(header.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("cannot fetch transactions for block %s: %w", number, err)
+		}
+
+		tips := make([]*big.Int, 0, len(txs))
+		for _, tx := range txs {
+			effectiveTip, err := tx.EffectiveGasTip(header.BaseFee)
+			if err != nil {
+				continue
+			}
+			tips = append(tips, effectiveTip)
+		}
+		if len(tips) > 0 {
+			sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+			index := len(tips) * int(percentile) / 100
+			if index >= len(tips) {
+				index = len(tips) - 1
+			}
+			perBlockSamples = append(perBlockSamples, tips[index])
+		}
+
+		number = new(big.Int).Sub(number, big.NewInt(1))
+	}
+
+	if len(perBlockSamples) == 0 {
+		return nil, errors.New("no priority fee samples collected")
+	}
+	sort.Slice(perBlockSamples, func(i, j int) bool { return perBlockSamples[i].Cmp(perBlockSamples[j]) < 0 })
+	return perBlockSamples[len(perBlockSamples)/2], nil
+}
@@ -0,0 +1,7 @@
+package oracle
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+// gasPriceGauge tracks the last L2 gas price read back from the
+// BVM_GasPriceOracle contract.
+var gasPriceGauge = metrics.NewRegisteredGauge("gasoracle/gasprice", nil)
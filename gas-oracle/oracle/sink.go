@@ -0,0 +1,172 @@
+package oracle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// PriceUpdate describes a single fee component that a loop has decided to
+// propose, before it is handed to a PriceSink for publishing.
+type PriceUpdate struct {
+	Layer           string
+	Component       string
+	Current         *big.Int
+	Proposed        *big.Int
+	EpochStartBlock uint64
+	Timestamp       int64
+}
+
+// PriceSink is the last step of a gas-oracle loop: given a proposed price,
+// it decides what to do with it. OnChainSink preserves the oracle's
+// original behavior of transacting against the L2 contract; the other
+// implementations let an operator shadow-run a configuration against
+// production data before trusting it to write on-chain.
+type PriceSink interface {
+	Publish(update PriceUpdate) error
+}
+
+// OnChainSink submits the proposed price on-chain via transact, which
+// callers supply bound to the specific contract setter for the component
+// being published.
+type OnChainSink struct {
+	transact func(update PriceUpdate) error
+}
+
+// NewOnChainSink creates an OnChainSink that publishes via transact.
+func NewOnChainSink(transact func(update PriceUpdate) error) *OnChainSink {
+	return &OnChainSink{transact: transact}
+}
+
+// Publish implements PriceSink.
+func (s *OnChainSink) Publish(update PriceUpdate) error {
+	return s.transact(update)
+}
+
+// LogSink logs the proposed price against the current one and never
+// transacts, for dry-running a configuration without touching the chain.
+type LogSink struct{}
+
+// NewLogSink creates a LogSink.
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+// Publish implements PriceSink.
+func (s *LogSink) Publish(update PriceUpdate) error {
+	log.Info("Simulated price update", "layer", update.Layer, "component", update.Component,
+		"current", update.Current, "proposed", update.Proposed, "epochStartBlock", update.EpochStartBlock)
+	return nil
+}
+
+// PrometheusSink exports the proposed price as a gauge per layer/component
+// without transacting, for wiring a shadow configuration into the same
+// dashboards as the live oracle.
+type PrometheusSink struct {
+	mu     sync.Mutex
+	gauges map[string]metrics.Gauge
+}
+
+// NewPrometheusSink creates a PrometheusSink.
+func NewPrometheusSink() *PrometheusSink {
+	return &PrometheusSink{gauges: make(map[string]metrics.Gauge)}
+}
+
+// Publish implements PriceSink.
+func (s *PrometheusSink) Publish(update PriceUpdate) error {
+	name := fmt.Sprintf("gasoracle/simulated/%s/%s", update.Layer, update.Component)
+
+	s.mu.Lock()
+	gauge, ok := s.gauges[name]
+	if !ok {
+		gauge = metrics.NewRegisteredGauge(name, nil)
+		s.gauges[name] = gauge
+	}
+	s.mu.Unlock()
+
+	gauge.Update(update.Proposed.Int64())
+	return nil
+}
+
+// WebhookSink POSTs the proposed price to an operator-configured URL as
+// JSON, for feeding a shadow configuration into external tooling.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a WebhookSink that POSTs to url.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{url: url, client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// Publish implements PriceSink.
+func (s *WebhookSink) Publish(update PriceUpdate) error {
+	body, err := json.Marshal(struct {
+		Layer           string `json:"layer"`
+		Current         string `json:"current"`
+		Proposed        string `json:"proposed"`
+		Timestamp       int64  `json:"timestamp"`
+		EpochStartBlock uint64 `json:"epochStartBlock"`
+	}{
+		Layer:           update.Layer,
+		Current:         bigIntString(update.Current),
+		Proposed:        bigIntString(update.Proposed),
+		Timestamp:       update.Timestamp,
+		EpochStartBlock: update.EpochStartBlock,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot marshal webhook payload: %w", err)
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook sink: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook sink: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func bigIntString(v *big.Int) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// newConfiguredSink resolves a Config sink selector to a PriceSink,
+// falling back to onChain (the loop's usual on-chain sink) for the ""
+// and "onchain" selectors.
+func newConfiguredSink(kind string, webhookURL string, onChain PriceSink) (PriceSink, error) {
+	switch kind {
+	case "", "onchain":
+		return onChain, nil
+	case "log":
+		return NewLogSink(), nil
+	case "prometheus":
+		return NewPrometheusSink(), nil
+	case "webhook":
+		if webhookURL == "" {
+			return nil, fmt.Errorf("webhook price sink requires Config.SinkWebhookURL to be set")
+		}
+		return NewWebhookSink(webhookURL), nil
+	default:
+		return nil, fmt.Errorf("unknown price sink %q", kind)
+	}
+}
+
+// isOnChainSink reports whether kind writes to the chain, i.e. is the
+// default sink or explicitly "onchain".
+func isOnChainSink(kind string) bool {
+	return kind == "" || kind == "onchain"
+}
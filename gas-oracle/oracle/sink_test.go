@@ -0,0 +1,101 @@
+package oracle
+
+import "testing"
+
+func TestNewConfiguredSink(t *testing.T) {
+	onChain := NewOnChainSink(func(update PriceUpdate) error { return nil })
+
+	tests := []struct {
+		name       string
+		kind       string
+		webhookURL string
+		wantErr    bool
+		check      func(t *testing.T, sink PriceSink)
+	}{
+		{
+			name: "empty selector falls back to onchain",
+			kind: "",
+			check: func(t *testing.T, sink PriceSink) {
+				if sink != onChain {
+					t.Fatal("expected the passed-in onChain sink")
+				}
+			},
+		},
+		{
+			name: "onchain selector falls back to onchain",
+			kind: "onchain",
+			check: func(t *testing.T, sink PriceSink) {
+				if sink != onChain {
+					t.Fatal("expected the passed-in onChain sink")
+				}
+			},
+		},
+		{
+			name: "log selector",
+			kind: "log",
+			check: func(t *testing.T, sink PriceSink) {
+				if _, ok := sink.(*LogSink); !ok {
+					t.Fatalf("got %T, want *LogSink", sink)
+				}
+			},
+		},
+		{
+			name: "prometheus selector",
+			kind: "prometheus",
+			check: func(t *testing.T, sink PriceSink) {
+				if _, ok := sink.(*PrometheusSink); !ok {
+					t.Fatalf("got %T, want *PrometheusSink", sink)
+				}
+			},
+		},
+		{
+			name:       "webhook selector with a configured URL",
+			kind:       "webhook",
+			webhookURL: "https://example.com/hook",
+			check: func(t *testing.T, sink PriceSink) {
+				if _, ok := sink.(*WebhookSink); !ok {
+					t.Fatalf("got %T, want *WebhookSink", sink)
+				}
+			},
+		},
+		{
+			name:    "webhook selector without a configured URL fails",
+			kind:    "webhook",
+			wantErr: true,
+		},
+		{
+			name:    "unknown selector fails",
+			kind:    "bogus",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, err := newConfiguredSink(tt.kind, tt.webhookURL, onChain)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newConfiguredSink: %v", err)
+			}
+			tt.check(t, sink)
+		})
+	}
+}
+
+func TestIsOnChainSink(t *testing.T) {
+	for _, kind := range []string{"", "onchain"} {
+		if !isOnChainSink(kind) {
+			t.Errorf("isOnChainSink(%q) = false, want true", kind)
+		}
+	}
+	for _, kind := range []string{"log", "prometheus", "webhook", "bogus"} {
+		if isOnChainSink(kind) {
+			t.Errorf("isOnChainSink(%q) = true, want false", kind)
+		}
+	}
+}